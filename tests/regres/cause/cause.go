@@ -21,19 +21,71 @@ import (
 )
 
 // Wrap returns a new error wrapping cause with the additional message.
+// The returned error captures the stack of the call to Wrap, which can be
+// retrieved with StackTrace or printed with the "%+v" verb.
 func Wrap(cause error, msg string, args ...interface{}) error {
-	s := fmt.Sprintf(msg, args...)
-	return fmt.Errorf("%v. Cause: %w", s, cause)
+	return &wrappedError{
+		msg:   fmt.Sprintf(msg, args...),
+		cause: cause,
+		stack: callers(),
+	}
 }
 
-// Merge merges all the errors into a single newline delimited error.
-func Merge(errs ...error) error {
-	if len(errs) == 0 {
-		return nil
+// New returns a new error with the given message, capturing the stack of
+// the call to New. Unlike errors.New, the error can be printed with "%+v"
+// to show the call stack that created it.
+func New(msg string, args ...interface{}) error {
+	return &wrappedError{
+		msg:   fmt.Sprintf(msg, args...),
+		stack: callers(),
 	}
-	strs := make([]string, len(errs))
-	for i, err := range errs {
+}
+
+// MultiError is an error that wraps zero or more errors, as returned by
+// Merge. It implements the Go 1.20 Unwrap() []error protocol so that
+// errors.Is and errors.As visit every wrapped error.
+type MultiError struct {
+	errs []error
+}
+
+// Error returns the newline delimited rendering of all the wrapped errors.
+func (m *MultiError) Error() string {
+	strs := make([]string, len(m.errs))
+	for i, err := range m.errs {
 		strs[i] = err.Error()
 	}
-	return fmt.Errorf("%v", strings.Join(strs, "\n"))
-}
\ No newline at end of file
+	return strings.Join(strs, "\n")
+}
+
+// Unwrap returns the errors wrapped by m, allowing errors.Is and errors.As
+// to examine each one.
+func (m *MultiError) Unwrap() []error { return m.errs }
+
+// Errors returns the errors wrapped by m.
+func (m *MultiError) Errors() []error { return m.errs }
+
+// WrappedErrors returns the errors wrapped by m. It exists for
+// compatibility with other multi-error implementations that expose this
+// accessor name.
+func (m *MultiError) WrappedErrors() []error { return m.errs }
+
+// Merge merges all the errors into a single MultiError, flattening any
+// nested MultiError values and dropping nils. Merge returns nil if errs is
+// empty or contains only nil errors.
+func Merge(errs ...error) error {
+	flat := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if m, ok := err.(*MultiError); ok {
+			flat = append(flat, m.errs...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	if len(flat) == 0 {
+		return nil
+	}
+	return &MultiError{errs: flat}
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMergeEmptyAndNil(t *testing.T) {
+	if err := Merge(); err != nil {
+		t.Errorf("Merge() = %v, want nil", err)
+	}
+	if err := Merge(nil, nil); err != nil {
+		t.Errorf("Merge(nil, nil) = %v, want nil", err)
+	}
+}
+
+func TestMergeFlattensNestedMultiError(t *testing.T) {
+	a := errors.New("a")
+	b := errors.New("b")
+	c := errors.New("c")
+
+	inner := Merge(a, b)
+	got := Merge(inner, nil, c)
+
+	m, ok := got.(*MultiError)
+	if !ok {
+		t.Fatalf("Merge() returned %T, want *MultiError", got)
+	}
+	want := []error{a, b, c}
+	if len(m.Errors()) != len(want) {
+		t.Fatalf("Merge() flattened to %v, want %v", m.Errors(), want)
+	}
+	for i, err := range want {
+		if m.Errors()[i] != err {
+			t.Errorf("Merge()[%d] = %v, want %v", i, m.Errors()[i], err)
+		}
+	}
+}
+
+func TestMergeErrorsIsAs(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	wrapped := Wrap(sentinel, "context")
+	var target *wrappedError
+
+	merged := Merge(errors.New("unrelated"), wrapped)
+
+	if !errors.Is(merged, sentinel) {
+		t.Errorf("errors.Is(merged, sentinel) = false, want true")
+	}
+	if !errors.As(merged, &target) {
+		t.Errorf("errors.As(merged, &target) = false, want true")
+	}
+}
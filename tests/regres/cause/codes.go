@@ -0,0 +1,109 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"errors"
+	"fmt"
+)
+
+// unknownCode is returned by Info when err carries no registered Code.
+var unknownCode = &Code{codespace: "undefined", code: 1, desc: "unknown"}
+
+// Code is a registered, machine-readable error identity, combining a
+// codespace (the subsystem that owns it, e.g. "build" or "device") with a
+// numeric code that is unique within that codespace. Code implements error
+// so it can be used directly, or wrapped with Wrap/Wrapf to add context
+// while keeping the identity intact.
+type Code struct {
+	codespace string
+	code      uint32
+	desc      string
+}
+
+// Register returns a new Code identified by codespace and code, described
+// by description. Registering the same codespace/code pair twice produces
+// distinct, unequal Codes; callers should keep a single shared *Code per
+// failure mode, typically in a package-level var.
+func Register(codespace string, code uint32, description string) *Code {
+	return &Code{codespace: codespace, code: code, desc: description}
+}
+
+// Error returns the description the Code was registered with.
+func (c *Code) Error() string { return c.desc }
+
+// Codespace returns the codespace c was registered under.
+func (c *Code) Codespace() string { return c.codespace }
+
+// Code returns the numeric code c was registered with.
+func (c *Code) Code() uint32 { return c.code }
+
+// Wrap returns a new error carrying c's identity through the Unwrap chain,
+// with the given message, capturing the stack of the call to Wrap.
+func (c *Code) Wrap(msg string, args ...interface{}) error {
+	return &codedError{
+		wrappedError: wrappedError{
+			msg:   fmt.Sprintf(msg, args...),
+			cause: c,
+			stack: callers(),
+		},
+		code: c,
+	}
+}
+
+// Wrapf is an alias for Wrap, provided for parity with the Wrapf naming
+// used elsewhere in error-code taxonomies such as cosmos-sdk/errors. It is
+// not implemented in terms of Wrap so that both capture the stack of the
+// caller's own frame rather than each other's.
+func (c *Code) Wrapf(msg string, args ...interface{}) error {
+	return &codedError{
+		wrappedError: wrappedError{
+			msg:   fmt.Sprintf(msg, args...),
+			cause: c,
+			stack: callers(),
+		},
+		code: c,
+	}
+}
+
+// codedError is a wrappedError whose innermost cause is always the Code
+// that identifies it, letting Info and Is recover that identity.
+type codedError struct {
+	wrappedError
+	code *Code
+}
+
+// Info walks the Unwrap chain of err looking for the innermost registered
+// Code, returning its codespace, numeric code and the log message produced
+// along the way. If no Code is found, Info returns the sentinel "unknown"
+// code's identity with err's own message as the log.
+func Info(err error) (codespace string, code uint32, log string) {
+	if err == nil {
+		return unknownCode.codespace, unknownCode.code, ""
+	}
+	found := unknownCode
+	Walk(err, func(e error) {
+		if c, ok := e.(*Code); ok {
+			found = c
+		}
+	})
+	return found.codespace, found.code, err.Error()
+}
+
+// Is returns true if err, or any error it wraps, is c, as determined by
+// errors.Is.
+func Is(err error, c *Code) bool {
+	return errors.Is(err, c)
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import "testing"
+
+var (
+	codeBuildFailed = Register("build", 1, "build failed")
+	codeDeviceLost  = Register("device", 2, "device lost")
+)
+
+func TestInfoFindsInnermostRegisteredCode(t *testing.T) {
+	err := Wrap(codeBuildFailed.Wrap("shader compile"), "pipeline creation")
+
+	codespace, code, _ := Info(err)
+	if codespace != "build" || code != 1 {
+		t.Errorf("Info() = (%q, %d), want (build, 1)", codespace, code)
+	}
+}
+
+func TestInfoDefaultsToUnknown(t *testing.T) {
+	err := Wrap(errFromString("plain"), "context")
+
+	codespace, code, _ := Info(err)
+	if codespace != unknownCode.codespace || code != unknownCode.code {
+		t.Errorf("Info() = (%q, %d), want (%q, %d)", codespace, code, unknownCode.codespace, unknownCode.code)
+	}
+}
+
+func TestIsMatchesRegisteredCode(t *testing.T) {
+	err := codeDeviceLost.Wrapf("lost device %d", 0)
+
+	if !Is(err, codeDeviceLost) {
+		t.Errorf("Is(err, codeDeviceLost) = false, want true")
+	}
+	if Is(err, codeBuildFailed) {
+		t.Errorf("Is(err, codeBuildFailed) = true, want false")
+	}
+}
+
+type errFromString string
+
+func (e errFromString) Error() string { return string(e) }
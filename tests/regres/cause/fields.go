@@ -0,0 +1,92 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fielder is implemented by errors that carry structured key/value
+// context, as returned by With. Fields returns it via the Unwrap chain so
+// callers can emit it, for example, as JSON log fields.
+type Fielder interface {
+	Fields() map[string]interface{}
+}
+
+// fieldedError is an error that annotates a cause with structured
+// key/value context.
+type fieldedError struct {
+	cause  error
+	fields map[string]interface{}
+}
+
+// With returns a new error that wraps err with the given key/value pairs,
+// e.g. cause.With(err, "shader", name, "stage", "spirv-opt"). kv must be an
+// even number of arguments, alternating string keys and values.
+func With(err error, kv ...interface{}) error {
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, _ := kv[i].(string)
+		fields[key] = kv[i+1]
+	}
+	return &fieldedError{cause: err, fields: fields}
+}
+
+// Error renders the fields as "key1=val1 key2=val2: <underlying>", with
+// keys sorted for a stable rendering.
+func (f *fieldedError) Error() string {
+	return fmt.Sprintf("%v: %v", f.formatFields(), f.cause)
+}
+
+func (f *fieldedError) formatFields() string {
+	keys := make([]string, 0, len(f.fields))
+	for k := range f.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%v=%v", k, f.fields[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Unwrap returns the error that f annotates.
+func (f *fieldedError) Unwrap() error { return f.cause }
+
+// Fields returns the key/value pairs attached by With.
+func (f *fieldedError) Fields() map[string]interface{} { return f.fields }
+
+// Fields returns the merged structured context attached to err and every
+// error it wraps, by cause.With. Fields are collected from the outermost
+// wrap inward; when two nodes share a key, the outer (earlier encountered)
+// value wins.
+func Fields(err error) map[string]interface{} {
+	out := map[string]interface{}{}
+	Walk(err, func(err error) {
+		fielder, ok := err.(Fielder)
+		if !ok {
+			return
+		}
+		for k, v := range fielder.Fields() {
+			if _, exists := out[k]; !exists {
+				out[k] = v
+			}
+		}
+	})
+	return out
+}
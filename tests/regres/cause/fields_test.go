@@ -0,0 +1,52 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithRendersSortedFields(t *testing.T) {
+	err := With(errors.New("boom"), "stage", "spirv-opt", "shader", "vs_main")
+	want := "shader=vs_main stage=spirv-opt: boom"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFieldsOuterWinsOnCollision(t *testing.T) {
+	inner := With(errors.New("boom"), "shader", "inner-val", "stage", "link")
+	outer := With(inner, "shader", "outer-val")
+
+	fields := Fields(outer)
+	if fields["shader"] != "outer-val" {
+		t.Errorf("fields[shader] = %v, want outer-val", fields["shader"])
+	}
+	if fields["stage"] != "link" {
+		t.Errorf("fields[stage] = %v, want link", fields["stage"])
+	}
+}
+
+func TestFieldsMergesAcrossMultiError(t *testing.T) {
+	a := With(errors.New("a"), "k1", "v1")
+	b := With(errors.New("b"), "k2", "v2")
+	merged := Merge(a, b)
+
+	fields := Fields(merged)
+	if fields["k1"] != "v1" || fields["k2"] != "v2" {
+		t.Errorf("Fields(merged) = %v, want k1=v1 k2=v2", fields)
+	}
+}
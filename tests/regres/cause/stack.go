@@ -0,0 +1,109 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// wrappedError is an error that wraps another error with a message and the
+// stack of the call that created it.
+type wrappedError struct {
+	msg   string
+	cause error
+	stack []uintptr
+}
+
+// Error returns the message of the error, followed by the cause, matching
+// the historic "msg. Cause: %v" rendering of Wrap.
+func (w *wrappedError) Error() string {
+	if w.cause == nil {
+		return w.msg
+	}
+	return fmt.Sprintf("%v. Cause: %v", w.msg, w.cause)
+}
+
+// Unwrap returns the error that w wraps, or nil if w was created by New.
+func (w *wrappedError) Unwrap() error { return w.cause }
+
+// Format implements fmt.Formatter. The "%+v" verb prints the message
+// followed by the symbolicated stack of the call that created w, then
+// recurses into the "%+v" formatting of the cause.
+func (w *wrappedError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, w.msg)
+		for _, frame := range w.frames() {
+			fmt.Fprintf(s, "\n  %v\n\t%v:%v", frame.Function, frame.File, frame.Line)
+		}
+		if w.cause != nil {
+			fmt.Fprintf(s, "\nCause: %+v", w.cause)
+		}
+	case verb == 's' || verb == 'v' || verb == 'q':
+		io.WriteString(s, w.Error())
+	}
+}
+
+// frames returns the symbolicated call stack captured when w was created.
+func (w *wrappedError) frames() []runtime.Frame {
+	frames := runtime.CallersFrames(w.stack)
+	out := make([]runtime.Frame, 0, len(w.stack))
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// callers captures the stack of the caller of the function that calls
+// callers(), skipping the runtime.Callers, callers() and immediate caller
+// frames.
+func callers() []uintptr {
+	var pc [32]uintptr
+	n := runtime.Callers(3, pc[:])
+	return pc[:n]
+}
+
+// StackTrace returns the symbolicated call stack frames carried by err and
+// every error it wraps through Unwrap() error, ordered from the outermost
+// wrap to the innermost. The errors wrapped by a MultiError are not
+// followed. Errors that were not created by Wrap, New, or a Code's Wrap or
+// Wrapf contribute no frames.
+func StackTrace(err error) []runtime.Frame {
+	var frames []runtime.Frame
+	for err != nil {
+		if c, ok := err.(*codedError); ok {
+			frames = append(frames, c.frames()...)
+			err = c.cause
+			continue
+		}
+		if w, ok := err.(*wrappedError); ok {
+			frames = append(frames, w.frames()...)
+			err = w.cause
+			continue
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		err = u.Unwrap()
+	}
+	return frames
+}
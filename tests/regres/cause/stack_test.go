@@ -0,0 +1,55 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWrapErrorMessage(t *testing.T) {
+	err := Wrap(fmt.Errorf("root"), "context %d", 1)
+	want := "context 1. Cause: root"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestWrapFormatPlusVIncludesStackAndCause(t *testing.T) {
+	err := Wrap(fmt.Errorf("root"), "context")
+	got := fmt.Sprintf("%+v", err)
+	if !strings.Contains(got, "context") {
+		t.Errorf("%%+v output %q missing message", got)
+	}
+	if !strings.Contains(got, "TestWrapFormatPlusVIncludesStackAndCause") {
+		t.Errorf("%%+v output %q missing caller frame", got)
+	}
+	if !strings.Contains(got, "Cause: root") {
+		t.Errorf("%%+v output %q missing cause", got)
+	}
+}
+
+func TestStackTraceCollectsEveryWrap(t *testing.T) {
+	inner := New("inner")
+	outer := Wrap(inner, "outer")
+	frames := StackTrace(outer)
+	if len(frames) == 0 {
+		t.Fatalf("StackTrace() returned no frames")
+	}
+	if !strings.Contains(frames[0].Function, "TestStackTraceCollectsEveryWrap") {
+		t.Errorf("frames[0] = %+v, want the Wrap call site", frames[0])
+	}
+}
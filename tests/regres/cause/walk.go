@@ -0,0 +1,109 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Walk calls fn once for every error reachable from err, including err
+// itself, by following both the Unwrap() error and Unwrap() []error
+// protocols. Each comparable node is visited exactly once, even if it is
+// reachable by more than one path; nodes of a non-comparable concrete type
+// (e.g. a struct error with a slice or map field) cannot be deduplicated
+// and are visited once per path.
+func Walk(err error, fn func(error)) {
+	visited := map[error]bool{}
+	seen := func(err error) bool {
+		// Only comparable errors can be used as map keys; a struct error
+		// with a slice or map field is legal but not comparable, so such
+		// errors are never deduplicated. errors.Is has the same caveat.
+		if !reflect.TypeOf(err).Comparable() {
+			return false
+		}
+		if visited[err] {
+			return true
+		}
+		visited[err] = true
+		return false
+	}
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil || seen(err) {
+			return
+		}
+		fn(err)
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, child := range u.Unwrap() {
+				walk(child)
+			}
+		}
+	}
+	walk(err)
+}
+
+// Contains returns true if the Error() string of err, or any error it
+// wraps, contains substr.
+func Contains(err error, substr string) bool {
+	found := false
+	Walk(err, func(err error) {
+		if !found && strings.Contains(err.Error(), substr) {
+			found = true
+		}
+	})
+	return found
+}
+
+// ContainsType returns true if err, or any error it wraps, has the same
+// concrete type as target.
+func ContainsType(err error, target interface{}) bool {
+	want := reflect.TypeOf(target)
+	found := false
+	Walk(err, func(err error) {
+		if !found && reflect.TypeOf(err) == want {
+			found = true
+		}
+	})
+	return found
+}
+
+// GetAll returns every error reachable from err, including err itself,
+// whose Error() string contains substr.
+func GetAll(err error, substr string) []error {
+	var out []error
+	Walk(err, func(err error) {
+		if strings.Contains(err.Error(), substr) {
+			out = append(out, err)
+		}
+	})
+	return out
+}
+
+// GetAllType returns every error reachable from err, including err itself,
+// that has the same concrete type as target.
+func GetAllType(err error, target interface{}) []error {
+	want := reflect.TypeOf(target)
+	var out []error
+	Walk(err, func(err error) {
+		if reflect.TypeOf(err) == want {
+			out = append(out, err)
+		}
+	})
+	return out
+}
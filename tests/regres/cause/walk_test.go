@@ -0,0 +1,79 @@
+// Copyright 2019 The SwiftShader Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cause
+
+import (
+	"errors"
+	"testing"
+)
+
+// uncomparableError has a slice field, making its concrete type
+// non-comparable. It is legal to use as an error.
+type uncomparableError struct {
+	tags []int
+}
+
+func (e uncomparableError) Error() string { return "uncomparable" }
+
+func TestWalkVisitsEveryNode(t *testing.T) {
+	leaf1 := errors.New("leaf1")
+	leaf2 := errors.New("leaf2")
+	merged := Merge(leaf1, leaf2)
+	root := Wrap(merged, "root")
+
+	var visited []error
+	Walk(root, func(err error) { visited = append(visited, err) })
+
+	if len(visited) != 4 { // root, MultiError, leaf1, leaf2
+		t.Fatalf("Walk visited %d nodes, want 4: %v", len(visited), visited)
+	}
+}
+
+func TestWalkDoesNotPanicOnUncomparableError(t *testing.T) {
+	err := Wrap(uncomparableError{tags: []int{1, 2, 3}}, "x")
+
+	var count int
+	Walk(err, func(err error) { count++ })
+
+	if count != 2 {
+		t.Errorf("Walk visited %d nodes, want 2", count)
+	}
+}
+
+func TestContainsAndGetAll(t *testing.T) {
+	err := Wrap(errors.New("disk full"), "write failed")
+
+	if !Contains(err, "disk full") {
+		t.Errorf("Contains() = false, want true")
+	}
+	if Contains(err, "out of memory") {
+		t.Errorf("Contains() = true, want false")
+	}
+	if got := GetAll(err, "failed"); len(got) != 1 {
+		t.Errorf("GetAll() = %v, want 1 match", got)
+	}
+}
+
+func TestContainsAndGetAllType(t *testing.T) {
+	leaf := uncomparableError{tags: []int{1}}
+	err := Wrap(leaf, "x")
+
+	if !ContainsType(err, uncomparableError{}) {
+		t.Errorf("ContainsType() = false, want true")
+	}
+	if got := GetAllType(err, uncomparableError{}); len(got) != 1 {
+		t.Errorf("GetAllType() = %v, want 1 match", got)
+	}
+}